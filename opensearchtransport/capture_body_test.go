@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+//
+// Modifications Copyright OpenSearch Contributors. See
+// GitHub history for details.
+
+package opensearchtransport
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordingContext returns a context carrying a span that IsRecording, so
+// captureBody and its callers exercise the attribute-recording path instead
+// of bailing out early.
+func recordingContext(t *testing.T) context.Context {
+	t.Helper()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	ctx, span := tp.Tracer(tracerName).Start(context.Background(), "test")
+	t.Cleanup(func() { span.End() })
+	return ctx
+}
+
+func TestCaptureBodyTruncationBoundary(t *testing.T) {
+	instr := NewOtelInstrumentation(nil, true, "v1")
+	instr.maxRecordedBodyBytes = 4
+
+	tests := []struct {
+		name          string
+		body          string
+		wantTruncated bool
+	}{
+		{name: "under cap", body: "abc", wantTruncated: false},
+		{name: "exactly at cap", body: "abcd", wantTruncated: false},
+		{name: "one over cap", body: "abcde", wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := recordingContext(t)
+			rc := instr.captureBody(ctx, attrDbStatement, attrDbStatementTruncated, strings.NewReader(tt.body), nil)
+			if rc == nil {
+				t.Fatal("captureBody returned nil on a recording span")
+			}
+			got, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != tt.body {
+				t.Errorf("replayed body = %q, want %q (full body must still be readable)", got, tt.body)
+			}
+			truncated := false
+			for _, kv := range trace.SpanFromContext(ctx).(sdktrace.ReadOnlySpan).Attributes() {
+				if string(kv.Key) == attrDbStatementTruncated {
+					truncated = kv.Value.AsBool()
+				}
+			}
+			if truncated != tt.wantTruncated {
+				t.Errorf("%s = %v, want %v", attrDbStatementTruncated, truncated, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestRecordResponseBodyDoubleReadReplay(t *testing.T) {
+	instr := NewOtelInstrumentation(nil, true, "v1")
+	ctx := recordingContext(t)
+
+	const want = "{\"took\":1}"
+	rc := instr.RecordResponseBody(ctx, "search", 200, io.NopCloser(strings.NewReader(want)))
+	if rc == nil {
+		t.Fatal("RecordResponseBody returned nil for an allow-listed endpoint")
+	}
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("replayed response body = %q, want %q", got, want)
+	}
+}
+
+func TestRecordResponseBodyUsesExplicitStatusCode(t *testing.T) {
+	instr := NewOtelInstrumentation(nil, false, "v1")
+	ctx := recordingContext(t)
+
+	if rc := instr.RecordResponseBody(ctx, "get", 200, io.NopCloser(strings.NewReader("ok"))); rc != nil {
+		t.Error("expected nil for a non-search, non-error endpoint")
+	}
+
+	rc := instr.RecordResponseBody(ctx, "get", 500, io.NopCloser(strings.NewReader("boom")))
+	if rc == nil {
+		t.Fatal("expected a replacement reader for an error response")
+	}
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(got) != "boom" {
+		t.Errorf("replayed body = %q, want %q", got, "boom")
+	}
+}
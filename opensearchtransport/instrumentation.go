@@ -19,6 +19,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -34,6 +35,19 @@ const attrHttpRequestMethod = "http.request.method"
 const attrUrlFull = "url.full"
 const attrServerAddress = "server.address"
 const attrServerPort = "server.port"
+const attrHttpResponseStatusCode = "http.response.status_code"
+const attrHttpRequestResendCount = "http.request.resend_count"
+const attrDbResponseStatement = "db.response.statement"
+const attrDbStatementTruncated = "db.statement.truncated"
+const attrDbResponseStatementTruncated = "db.response.statement.truncated"
+
+// defaultMaxRecordedBodyBytes is the cap used when WithMaxRecordedBodyBytes is not
+// set, or is set to a value <= 0.
+const defaultMaxRecordedBodyBytes = 64 * 1024
+
+// retryCountContextKey is the context key the transport client uses to record
+// how many times the request was retried before this response was received.
+type retryCountContextKey struct{}
 
 // Instrumentation defines the interface the client uses to propagate information about the requests.
 // Each method is called with the current context or request for propagation.
@@ -50,6 +64,11 @@ type Instrumentation interface {
 	// RecordRequestBody records the current request payload.
 	RecordRequestBody(ctx context.Context, endpoint string, query io.Reader) io.ReadCloser
 
+	// RecordResponseBody records the response payload for the given endpoint and status
+	// code, e.g. to attach an error response body to the span for debugging. Returns a
+	// replacement for body if the response has been recorded, nil otherwise.
+	RecordResponseBody(ctx context.Context, endpoint string, statusCode int, body io.ReadCloser) io.ReadCloser
+
 	// BeforeRequest provides the request called before sending to the server.
 	BeforeRequest(req *http.Request)
 
@@ -62,42 +81,101 @@ type Instrumentation interface {
 }
 
 type OpensearchOpenTelemetry struct {
-	tracer     trace.Tracer
-	recordBody bool
+	tracer               trace.Tracer
+	recordBody           bool
+	propagator           propagation.TextMapPropagator
+	spanStartOptions     []trace.SpanStartOption
+	spanNameFormatter    func(operation string, req *http.Request) string
+	publicEndpoint       bool
+	publicEndpointFn     func() bool
+	recordedEndpoints    map[string]struct{}
+	maxRecordedBodyBytes int
 }
 
-// NewOtelInstrumentation returns a new instrument for Open Telemetry traces
-// If no provider is passed, the instrumentation will fall back to the global otel provider.
-// captureSearchBody sets the query capture behavior for search endpoints.
-// version should be set to the version provided by the caller.
+// NewOtelInstrumentation returns a new instrument for Open Telemetry traces.
+// If no tracer provider is passed, the instrumentation will fall back to the global otel provider.
 func NewOtelInstrumentation(provider trace.TracerProvider, captureSearchBody bool, version string) *OpensearchOpenTelemetry {
-	if provider == nil {
-		provider = otel.GetTracerProvider()
+	return NewOtelInstrumentationWithOptions(
+		WithTracerProvider(provider),
+		WithCaptureSearchBody(captureSearchBody),
+		WithVersion(version),
+	)
+}
+
+// NewOtelInstrumentationWithOptions returns a new instrument for Open Telemetry traces,
+// configured through the provided OtelOptions.
+// If no tracer provider is passed, the instrumentation will fall back to the global otel provider.
+// If no propagator is passed, the instrumentation will fall back to the global otel propagator.
+func NewOtelInstrumentationWithOptions(opts ...OtelOption) *OpensearchOpenTelemetry {
+	cfg := otelConfig{
+		provider:             otel.GetTracerProvider(),
+		propagator:           otel.GetTextMapPropagator(),
+		maxRecordedBodyBytes: defaultMaxRecordedBodyBytes,
 	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	return &OpensearchOpenTelemetry{
-		tracer: provider.Tracer(
+		tracer: cfg.provider.Tracer(
 			tracerName,
-			trace.WithInstrumentationVersion(version),
+			trace.WithInstrumentationVersion(cfg.version),
 			trace.WithSchemaURL(schemaUrl),
 		),
-		recordBody: captureSearchBody,
+		recordBody:           cfg.captureSearchBody,
+		propagator:           cfg.propagator,
+		spanStartOptions:     cfg.spanStartOptions,
+		spanNameFormatter:    cfg.spanNameFormatter,
+		publicEndpoint:       cfg.publicEndpoint,
+		publicEndpointFn:     cfg.publicEndpointFn,
+		recordedEndpoints:    cfg.recordedEndpoints,
+		maxRecordedBodyBytes: cfg.maxRecordedBodyBytes,
 	}
 }
 
 // Start begins a new span in the given context with the provided operation.
 // Span will always have a kind set to trace.SpanKindClient.
+// If a public endpoint predicate is configured and reports true for the current context,
+// the span links to the remote span context instead of parenting to it.
 // The context span aware is returned for use within the client.
 func (i OpensearchOpenTelemetry) Start(ctx context.Context, operation string) context.Context {
-	newCtx, _ := i.tracer.Start(ctx, operation,
+	opts := append([]trace.SpanStartOption{
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(
-				attribute.String(attrDbSystem, "opensearch"),
-				attribute.String(attrDbOperation, operation),
+			attribute.String(attrDbSystem, "opensearch"),
+			attribute.String(attrDbOperation, operation),
 		),
-	)
+	}, i.spanStartOptions...)
+
+	if i.isPublicEndpoint(ctx) {
+		remoteSpan := trace.SpanContextFromContext(ctx)
+		if remoteSpan.IsValid() {
+			opts = append(opts, trace.WithNewRoot(), trace.WithLinks(trace.Link{SpanContext: remoteSpan}))
+		}
+		ctx = trace.ContextWithSpanContext(ctx, trace.SpanContext{})
+	}
+
+	newCtx, _ := i.tracer.Start(ctx, operation, opts...)
+	newCtx = context.WithValue(newCtx, operationCtxKey{}, operation)
 	return newCtx
 }
 
+// isPublicEndpoint reports whether the current call should link to, rather than
+// parent from, any remote span context already present in ctx.
+func (i OpensearchOpenTelemetry) isPublicEndpoint(ctx context.Context) bool {
+	if i.publicEndpoint {
+		return true
+	}
+	if i.publicEndpointFn != nil {
+		return i.publicEndpointFn()
+	}
+	return false
+}
+
+// operationCtxKey is the context key used to recover the operation name passed to
+// Start so it can be handed to the span name formatter once the request is built.
+type operationCtxKey struct{}
+
 // Close call for the end of the span, preferably defered by the client once started.
 func (i OpensearchOpenTelemetry) Close(ctx context.Context) {
 	span := trace.SpanFromContext(ctx)
@@ -106,48 +184,106 @@ func (i OpensearchOpenTelemetry) Close(ctx context.Context) {
 	}
 }
 
-// shouldRecordRequestBody filters for search endpoints.
+// defaultRecordedEndpoints is the allow list of endpoints that will propagate
+// query to OpenTelemetry.
+// see https://opentelemetry.io/docs/specs/semconv/database/elasticsearch/#call-level-attributes
+var defaultRecordedEndpoints = map[string]struct{}{
+	"search":                 {},
+	"msearch":                {},
+	"terms_enum":             {},
+	"search_template":        {},
+	"msearch_template":       {},
+	"render_search_template": {},
+}
+
+// shouldRecordRequestBody filters for search endpoints, plus any endpoints added
+// through WithRecordedEndpoints.
 func (i OpensearchOpenTelemetry) shouldRecordRequestBody(endpoint string) bool {
-	// allow list of endpoints that will propagate query to OpenTelemetry.
-	// see https://opentelemetry.io/docs/specs/semconv/database/elasticsearch/#call-level-attributes
-	var searchEndpoints = map[string]struct{}{
-		"search":                 {},
-		"msearch":                {},
-		"terms_enum":             {},
-		"search_template":        {},
-		"msearch_template":       {},
-		"render_search_template": {},
-	}
-
-	if i.recordBody {
-		if _, ok := searchEndpoints[endpoint]; ok {
-			return true
-		}
+	if !i.recordBody {
+		return false
+	}
+	if _, ok := defaultRecordedEndpoints[endpoint]; ok {
+		return true
+	}
+	if _, ok := i.recordedEndpoints[endpoint]; ok {
+		return true
 	}
 	return false
 }
 
-// RecordRequestBody add the db.statement attributes only for search endpoints.
-// Returns a new reader if the query has been recorded, nil otherwise.
+// shouldRecordResponseBody reports whether the response body for endpoint should be
+// recorded: either because it is in the search-endpoint allow list, like request
+// bodies, or because the response is an error, regardless of the allow list.
+func (i OpensearchOpenTelemetry) shouldRecordResponseBody(endpoint string, statusCode int) bool {
+	return i.shouldRecordRequestBody(endpoint) || statusCode >= 400
+}
+
+// RecordRequestBody add the db.statement attribute, capped at maxRecordedBodyBytes,
+// only for search endpoints. Returns a new reader carrying the full, unmodified
+// query if the body has been recorded, nil otherwise.
 func (i OpensearchOpenTelemetry) RecordRequestBody(ctx context.Context, endpoint string, query io.Reader) io.ReadCloser {
 	if !i.shouldRecordRequestBody(endpoint) {
 		return nil
 	}
+	return i.captureBody(ctx, attrDbStatement, attrDbStatementTruncated, query, nil)
+}
+
+// RecordResponseBody adds the db.response.statement attribute, capped at
+// maxRecordedBodyBytes, for search endpoints and error responses (status >= 400),
+// self-gating on shouldRecordResponseBody the same way RecordRequestBody self-gates
+// on shouldRecordRequestBody. Returns a new reader carrying the full, unmodified
+// body if it has been recorded, nil otherwise.
+func (i OpensearchOpenTelemetry) RecordResponseBody(ctx context.Context, endpoint string, statusCode int, body io.ReadCloser) io.ReadCloser {
+	if body == nil {
+		return nil
+	}
+	if !i.shouldRecordResponseBody(endpoint, statusCode) {
+		return nil
+	}
+	return i.captureBody(ctx, attrDbResponseStatement, attrDbResponseStatementTruncated, body, body)
+}
 
+// captureBody reads up to maxRecordedBodyBytes+1 bytes from reader, records them as
+// the attrKey attribute on the active span (marking it via truncatedAttrKey when the
+// body exceeded the cap), and returns a reader that replays the captured bytes
+// followed by whatever remains of reader, so the caller still sees the full body.
+// closer, if non-nil, is closed when the returned reader is closed.
+func (i OpensearchOpenTelemetry) captureBody(ctx context.Context, attrKey, truncatedAttrKey string, reader io.Reader, closer io.Closer) io.ReadCloser {
 	span := trace.SpanFromContext(ctx)
-	if span.IsRecording() {
-		buf := bytes.Buffer{}
-		buf.ReadFrom(query)
-		span.SetAttributes(attribute.String(attrDbStatement, buf.String()))
-		getBody := func() (io.ReadCloser, error) {
-			reader := buf
-			return io.NopCloser(&reader), nil
-		}
-		reader, _ := getBody()
-		return reader
+	if !span.IsRecording() {
+		return nil
+	}
+
+	limit := i.maxRecordedBodyBytes
+	if limit <= 0 {
+		limit = defaultMaxRecordedBodyBytes
+	}
+
+	buf := bytes.Buffer{}
+	if _, err := buf.ReadFrom(io.LimitReader(reader, int64(limit)+1)); err != nil {
+		i.RecordError(ctx, err)
+	}
+
+	captured := buf.Bytes()
+	truncated := len(captured) > limit
+	statement := captured
+	if truncated {
+		statement = captured[:limit]
+	}
+
+	span.SetAttributes(attribute.String(attrKey, string(statement)))
+	if truncated {
+		span.SetAttributes(attribute.Bool(truncatedAttrKey, true))
 	}
 
-	return nil
+	full := io.MultiReader(bytes.NewReader(captured), reader)
+	if closer == nil {
+		return io.NopCloser(full)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{full, closer}
 }
 
 // RecordError sets any provided error as an OTel error in the active span.
@@ -159,8 +295,15 @@ func (i OpensearchOpenTelemetry) RecordError(ctx context.Context, err error) {
 	}
 }
 
-// BeforeRequest noop for interface.
-func (i OpensearchOpenTelemetry) BeforeRequest(req *http.Request) {}
+// BeforeRequest injects the active span's TraceContext and Baggage into the
+// outgoing request headers via the configured propagator, so downstream
+// OpenSearch ingest/search pipelines can correlate spans.
+func (i OpensearchOpenTelemetry) BeforeRequest(req *http.Request) {
+	if i.propagator == nil {
+		return
+	}
+	i.propagator.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+}
 
 // AfterRequest enrich the span with the available data from the request.
 func (i OpensearchOpenTelemetry) AfterRequest(req *http.Request) {
@@ -174,8 +317,38 @@ func (i OpensearchOpenTelemetry) AfterRequest(req *http.Request) {
 		if value, err := strconv.ParseInt(req.URL.Port(), 10, 32); err == nil {
 			span.SetAttributes(attribute.Int64(attrServerPort, value))
 		}
+		if i.spanNameFormatter != nil {
+			operation, _ := req.Context().Value(operationCtxKey{}).(string)
+			span.SetName(i.spanNameFormatter(operation, req))
+		}
 	}
 }
 
-// AfterResponse noop for interface.
-func (i OpensearchOpenTelemetry) AfterResponse(ctx context.Context, res *http.Response) {}
\ No newline at end of file
+// AfterResponse enriches the span with the available data from the response:
+// status code and the retry count recorded by the transport. The span is
+// marked as errored when the status code is >= 400.
+//
+// Cluster/node identity is deliberately not recorded here: OpenSearch does
+// not return a header that actually carries it, so attaching one would mean
+// either leaving the attribute empty or populating it from something that
+// isn't cluster/node identity (e.g. an echoed, caller-controlled opaque id).
+func (i OpensearchOpenTelemetry) AfterResponse(ctx context.Context, res *http.Response) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(attribute.Int(attrHttpResponseStatusCode, res.StatusCode))
+	if res.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	}
+
+	if retries, ok := ctx.Value(retryCountContextKey{}).(int); ok && retries > 0 {
+		span.SetAttributes(attribute.Int(attrHttpRequestResendCount, retries))
+	}
+
+	endpoint, _ := ctx.Value(operationCtxKey{}).(string)
+	if recorded := i.RecordResponseBody(ctx, endpoint, res.StatusCode, res.Body); recorded != nil {
+		res.Body = recorded
+	}
+}
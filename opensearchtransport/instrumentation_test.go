@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+//
+// Modifications Copyright OpenSearch Contributors. See
+// GitHub history for details.
+
+package opensearchtransport
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+// TestNewOtelInstrumentationSignature pins the original positional
+// constructor signature, so a future change can't silently break existing
+// callers.
+func TestNewOtelInstrumentationSignature(t *testing.T) {
+	instr := NewOtelInstrumentation(otel.GetTracerProvider(), true, "v1.2.3")
+	if instr == nil {
+		t.Fatal("NewOtelInstrumentation returned nil")
+	}
+	if !instr.recordBody {
+		t.Error("expected recordBody to be true")
+	}
+}
+
+func TestIsPublicEndpoint(t *testing.T) {
+	tests := []struct {
+		name string
+		i    OpensearchOpenTelemetry
+		want bool
+	}{
+		{
+			name: "no config",
+			i:    OpensearchOpenTelemetry{},
+			want: false,
+		},
+		{
+			name: "always public",
+			i:    OpensearchOpenTelemetry{publicEndpoint: true},
+			want: true,
+		},
+		{
+			name: "fn reports public",
+			i:    OpensearchOpenTelemetry{publicEndpointFn: func() bool { return true }},
+			want: true,
+		},
+		{
+			name: "fn reports private",
+			i:    OpensearchOpenTelemetry{publicEndpointFn: func() bool { return false }},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.i.isPublicEndpoint(context.Background()); got != tt.want {
+				t.Errorf("isPublicEndpoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWithPublicEndpointFnNoRequest verifies that WithPublicEndpointFn can be
+// configured with a closure that never receives a *http.Request (it is
+// evaluated from Start, before the outgoing request exists) without panicking.
+func TestWithPublicEndpointFnNoRequest(t *testing.T) {
+	instr := NewOtelInstrumentationWithOptions(WithPublicEndpointFn(func() bool { return true }))
+	if !instr.isPublicEndpoint(context.Background()) {
+		t.Error("expected isPublicEndpoint to report true")
+	}
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+//
+// Modifications Copyright OpenSearch Contributors. See
+// GitHub history for details.
+
+package opensearchtransport
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// MultiInstrumentation fans out every Instrumentation call to a set of
+// Instrumentation implementations, so a client can be instrumented with, for
+// example, both distributed tracing (OpensearchOpenTelemetry) and metrics
+// (OtelMetrics) at the same time.
+type MultiInstrumentation struct {
+	instrumentations []Instrumentation
+}
+
+// NewMultiInstrumentation returns an Instrumentation that fans out each call
+// to every instrumentation passed in, in order.
+func NewMultiInstrumentation(instrumentations ...Instrumentation) *MultiInstrumentation {
+	return &MultiInstrumentation{instrumentations: instrumentations}
+}
+
+// Start calls Start on every instrumentation, threading the context returned
+// by one into the next.
+func (m *MultiInstrumentation) Start(ctx context.Context, operation string) context.Context {
+	for _, instr := range m.instrumentations {
+		ctx = instr.Start(ctx, operation)
+	}
+	return ctx
+}
+
+// Close calls Close on every instrumentation.
+func (m *MultiInstrumentation) Close(ctx context.Context) {
+	for _, instr := range m.instrumentations {
+		instr.Close(ctx)
+	}
+}
+
+// RecordError calls RecordError on every instrumentation.
+func (m *MultiInstrumentation) RecordError(ctx context.Context, err error) {
+	for _, instr := range m.instrumentations {
+		instr.RecordError(ctx, err)
+	}
+}
+
+// RecordRequestBody calls RecordRequestBody on every instrumentation,
+// chaining the returned reader (if any) into the next call so each
+// instrumentation observes the same bytes. Returns the last non-nil reader.
+func (m *MultiInstrumentation) RecordRequestBody(ctx context.Context, endpoint string, query io.Reader) io.ReadCloser {
+	var body io.ReadCloser
+	for _, instr := range m.instrumentations {
+		if recorded := instr.RecordRequestBody(ctx, endpoint, query); recorded != nil {
+			body = recorded
+			query = recorded
+		}
+	}
+	return body
+}
+
+// RecordResponseBody calls RecordResponseBody on every instrumentation, chaining the
+// returned reader (if any) into the next call so each instrumentation observes the
+// same bytes. Returns the last non-nil reader.
+func (m *MultiInstrumentation) RecordResponseBody(ctx context.Context, endpoint string, statusCode int, body io.ReadCloser) io.ReadCloser {
+	var recorded io.ReadCloser
+	for _, instr := range m.instrumentations {
+		if r := instr.RecordResponseBody(ctx, endpoint, statusCode, body); r != nil {
+			recorded = r
+			body = r
+		}
+	}
+	return recorded
+}
+
+// BeforeRequest calls BeforeRequest on every instrumentation.
+func (m *MultiInstrumentation) BeforeRequest(req *http.Request) {
+	for _, instr := range m.instrumentations {
+		instr.BeforeRequest(req)
+	}
+}
+
+// AfterRequest calls AfterRequest on every instrumentation.
+func (m *MultiInstrumentation) AfterRequest(req *http.Request) {
+	for _, instr := range m.instrumentations {
+		instr.AfterRequest(req)
+	}
+}
+
+// AfterResponse calls AfterResponse on every instrumentation.
+func (m *MultiInstrumentation) AfterResponse(ctx context.Context, res *http.Response) {
+	for _, instr := range m.instrumentations {
+		instr.AfterResponse(ctx, res)
+	}
+}
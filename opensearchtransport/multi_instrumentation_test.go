@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+//
+// Modifications Copyright OpenSearch Contributors. See
+// GitHub history for details.
+
+package opensearchtransport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakeInstrumentation records every call it receives, so tests can assert
+// MultiInstrumentation reaches every configured sub-instrumentation.
+type fakeInstrumentation struct {
+	calls        []string
+	startCtxKey  interface{}
+	responseBody io.ReadCloser
+}
+
+func (f *fakeInstrumentation) Start(ctx context.Context, operation string) context.Context {
+	f.calls = append(f.calls, "Start")
+	return context.WithValue(ctx, f.startCtxKey, operation)
+}
+
+func (f *fakeInstrumentation) Close(ctx context.Context) { f.calls = append(f.calls, "Close") }
+
+func (f *fakeInstrumentation) RecordError(ctx context.Context, err error) {
+	f.calls = append(f.calls, "RecordError")
+}
+
+func (f *fakeInstrumentation) RecordRequestBody(ctx context.Context, endpoint string, query io.Reader) io.ReadCloser {
+	f.calls = append(f.calls, "RecordRequestBody")
+	return nil
+}
+
+func (f *fakeInstrumentation) RecordResponseBody(ctx context.Context, endpoint string, statusCode int, body io.ReadCloser) io.ReadCloser {
+	f.calls = append(f.calls, "RecordResponseBody")
+	return f.responseBody
+}
+
+func (f *fakeInstrumentation) BeforeRequest(req *http.Request) { f.calls = append(f.calls, "BeforeRequest") }
+
+func (f *fakeInstrumentation) AfterRequest(req *http.Request) { f.calls = append(f.calls, "AfterRequest") }
+
+func (f *fakeInstrumentation) AfterResponse(ctx context.Context, res *http.Response) {
+	f.calls = append(f.calls, "AfterResponse")
+}
+
+func TestMultiInstrumentationFansOutToEverySubInstrumentation(t *testing.T) {
+	a := &fakeInstrumentation{startCtxKey: struct{ name string }{"a"}}
+	b := &fakeInstrumentation{startCtxKey: struct{ name string }{"b"}}
+	m := NewMultiInstrumentation(a, b)
+
+	ctx := m.Start(context.Background(), "search")
+	m.Close(ctx)
+	m.RecordError(ctx, nil)
+	m.RecordRequestBody(ctx, "search", strings.NewReader("q"))
+	m.RecordResponseBody(ctx, "search", 200, io.NopCloser(strings.NewReader("r")))
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:9200/", nil)
+	m.BeforeRequest(req)
+	m.AfterRequest(req)
+	m.AfterResponse(ctx, &http.Response{StatusCode: 200})
+
+	wantCalls := []string{
+		"Start", "Close", "RecordError", "RecordRequestBody",
+		"RecordResponseBody", "BeforeRequest", "AfterRequest", "AfterResponse",
+	}
+	for _, instr := range []*fakeInstrumentation{a, b} {
+		if len(instr.calls) != len(wantCalls) {
+			t.Fatalf("calls = %v, want %v", instr.calls, wantCalls)
+		}
+		for i, want := range wantCalls {
+			if instr.calls[i] != want {
+				t.Errorf("calls[%d] = %q, want %q", i, instr.calls[i], want)
+			}
+		}
+	}
+
+	// Start must thread the returned context from one instrumentation into the
+	// next: b.Start should see the context a.Start returned.
+	if ctx.Value(a.startCtxKey) != "search" {
+		t.Error("context returned by a.Start did not reach the final context")
+	}
+	if ctx.Value(b.startCtxKey) != "search" {
+		t.Error("context returned by b.Start did not reach the final context")
+	}
+}
+
+func TestMultiInstrumentationRecordResponseBodyReturnsLastNonNil(t *testing.T) {
+	firstBody := io.NopCloser(strings.NewReader("first"))
+	a := &fakeInstrumentation{responseBody: firstBody}
+	b := &fakeInstrumentation{responseBody: nil}
+	m := NewMultiInstrumentation(a, b)
+
+	got := m.RecordResponseBody(context.Background(), "search", 200, io.NopCloser(strings.NewReader("orig")))
+	if got != firstBody {
+		t.Errorf("RecordResponseBody() = %v, want the last non-nil reader (%v)", got, firstBody)
+	}
+}
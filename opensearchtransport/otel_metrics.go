@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+//
+// Modifications Copyright OpenSearch Contributors. See
+// GitHub history for details.
+
+package opensearchtransport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "opensearch-api"
+
+// Metric names, following the OTel semantic conventions for database client
+// metrics. see https://opentelemetry.io/docs/specs/semconv/database/database-metrics/
+const metricOperationDuration = "db.client.operation.duration"
+const metricRequestRetries = "db.client.request.retries"
+const metricConnectionPoolSize = "db.client.connection.pool.size"
+const metricConnectionsDead = "db.client.connections.dead"
+const metricRequestBodySize = "db.client.request.body.size"
+const metricResponseBodySize = "db.client.response.body.size"
+
+// PoolStatser is implemented by connection pools that can report their
+// current size and number of dead connections. OtelMetrics uses it to back
+// the connection.pool.size and connections.dead gauges without depending on
+// a concrete pool implementation.
+type PoolStatser interface {
+	PoolSize() int
+	DeadConnections() int
+}
+
+// OtelMetrics is an Instrumentation implementation that emits OpenTelemetry
+// metrics instead of (or alongside, via MultiInstrumentation) traces.
+type OtelMetrics struct {
+	operationDuration metric.Float64Histogram
+	requestRetries    metric.Int64Counter
+	requestBodySize   metric.Int64Histogram
+	responseBodySize  metric.Int64Histogram
+
+	poolMu sync.RWMutex
+	pool   PoolStatser
+}
+
+// otelMetricsState carries the data gathered across the Instrumentation
+// lifecycle methods for a single request, from Start through Close.
+type otelMetricsState struct {
+	operation     string
+	startTime     time.Time
+	serverAddress string
+	statusCode    int
+}
+
+type metricsStateCtxKey struct{}
+
+// NewOtelMetrics returns a new Instrumentation that records OTel metrics.
+// If no provider is passed, the instrumentation will fall back to the global
+// otel meter provider. Use SetConnectionPool once the transport's connection
+// pool is available to back the connection.pool.size and connections.dead
+// gauges.
+func NewOtelMetrics(provider metric.MeterProvider) Instrumentation {
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+	meter := provider.Meter(meterName)
+
+	operationDuration, _ := meter.Float64Histogram(
+		metricOperationDuration,
+		metric.WithDescription("Duration of OpenSearch client operations"),
+		metric.WithUnit("ms"),
+	)
+	requestRetries, _ := meter.Int64Counter(
+		metricRequestRetries,
+		metric.WithDescription("Number of times a request was retried"),
+	)
+	requestBodySize, _ := meter.Int64Histogram(
+		metricRequestBodySize,
+		metric.WithDescription("Size of the request body"),
+		metric.WithUnit("By"),
+	)
+	responseBodySize, _ := meter.Int64Histogram(
+		metricResponseBodySize,
+		metric.WithDescription("Size of the response body"),
+		metric.WithUnit("By"),
+	)
+
+	m := &OtelMetrics{
+		operationDuration: operationDuration,
+		requestRetries:    requestRetries,
+		requestBodySize:   requestBodySize,
+		responseBodySize:  responseBodySize,
+	}
+
+	meter.Int64ObservableGauge(
+		metricConnectionPoolSize,
+		metric.WithDescription("Number of connections in the pool"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			if pool := m.connectionPool(); pool != nil {
+				o.Observe(int64(pool.PoolSize()))
+			}
+			return nil
+		}),
+	)
+	meter.Int64ObservableGauge(
+		metricConnectionsDead,
+		metric.WithDescription("Number of connections marked dead in the pool"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			if pool := m.connectionPool(); pool != nil {
+				o.Observe(int64(pool.DeadConnections()))
+			}
+			return nil
+		}),
+	)
+
+	return m
+}
+
+// SetConnectionPool wires the connection pool the connection.pool.size and
+// connections.dead gauges report on. Safe to call concurrently with the
+// SDK's collection of those gauges.
+func (m *OtelMetrics) SetConnectionPool(pool PoolStatser) {
+	m.poolMu.Lock()
+	defer m.poolMu.Unlock()
+	m.pool = pool
+}
+
+// connectionPool returns the currently configured connection pool, if any.
+func (m *OtelMetrics) connectionPool() PoolStatser {
+	m.poolMu.RLock()
+	defer m.poolMu.RUnlock()
+	return m.pool
+}
+
+// Start records the operation start time so Close can compute its duration.
+func (m *OtelMetrics) Start(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, metricsStateCtxKey{}, &otelMetricsState{
+		operation: operation,
+		startTime: time.Now(),
+	})
+}
+
+// Close records the operation duration, tagged by operation, server address
+// and response status code gathered over the lifecycle of the request.
+func (m *OtelMetrics) Close(ctx context.Context) {
+	state, ok := ctx.Value(metricsStateCtxKey{}).(*otelMetricsState)
+	if !ok {
+		return
+	}
+
+	m.operationDuration.Record(ctx, float64(time.Since(state.startTime).Milliseconds()), metric.WithAttributes(
+		attribute.String(attrDbOperation, state.operation),
+		attribute.String(attrServerAddress, state.serverAddress),
+		attribute.Int(attrHttpResponseStatusCode, state.statusCode),
+	))
+}
+
+// RecordError is a no-op: errors surface through the response status code
+// recorded in AfterResponse.
+func (m *OtelMetrics) RecordError(ctx context.Context, err error) {}
+
+// RecordRequestBody wraps query in a counting reader so its size can be
+// recorded to the request.body.size histogram once it has been fully read.
+// Returns nil if query is nil, e.g. for requests with no body.
+func (m *OtelMetrics) RecordRequestBody(ctx context.Context, endpoint string, query io.Reader) io.ReadCloser {
+	if query == nil {
+		return nil
+	}
+	return &countingReadCloser{
+		ctx:     ctx,
+		reader:  query,
+		onClose: m.requestBodySize,
+	}
+}
+
+// RecordResponseBody noop for interface: OtelMetrics does not capture payloads.
+func (m *OtelMetrics) RecordResponseBody(ctx context.Context, endpoint string, statusCode int, body io.ReadCloser) io.ReadCloser {
+	return nil
+}
+
+// BeforeRequest noop for interface.
+func (m *OtelMetrics) BeforeRequest(req *http.Request) {}
+
+// AfterRequest records the server address the request was sent to.
+func (m *OtelMetrics) AfterRequest(req *http.Request) {
+	state, ok := req.Context().Value(metricsStateCtxKey{}).(*otelMetricsState)
+	if !ok {
+		return
+	}
+	state.serverAddress = req.URL.Hostname()
+}
+
+// AfterResponse records the response status code for the in-flight operation,
+// the number of retries performed, if any, and, when known, the response body
+// size.
+func (m *OtelMetrics) AfterResponse(ctx context.Context, res *http.Response) {
+	state, ok := ctx.Value(metricsStateCtxKey{}).(*otelMetricsState)
+	if !ok {
+		return
+	}
+	state.statusCode = res.StatusCode
+
+	if retries, ok := ctx.Value(retryCountContextKey{}).(int); ok && retries > 0 {
+		m.requestRetries.Add(ctx, int64(retries), metric.WithAttributes(
+			attribute.String(attrDbOperation, state.operation),
+			attribute.String(attrServerAddress, state.serverAddress),
+		))
+	}
+
+	if res.ContentLength >= 0 {
+		m.responseBodySize.Record(ctx, res.ContentLength, metric.WithAttributes(
+			attribute.String(attrDbOperation, state.operation),
+		))
+	}
+}
+
+// countingReadCloser records the total number of bytes read from reader to
+// the onClose histogram once the caller reaches EOF or closes it.
+type countingReadCloser struct {
+	ctx     context.Context
+	reader  io.Reader
+	onClose metric.Int64Histogram
+	read    int64
+	done    bool
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.read += int64(n)
+	if err == io.EOF {
+		c.record()
+	}
+	return n, err
+}
+
+func (c *countingReadCloser) Close() error {
+	c.record()
+	if rc, ok := c.reader.(io.Closer); ok {
+		return rc.Close()
+	}
+	return nil
+}
+
+func (c *countingReadCloser) record() {
+	if c.done {
+		return
+	}
+	c.done = true
+	if c.onClose != nil {
+		c.onClose.Record(c.ctx, c.read)
+	}
+}
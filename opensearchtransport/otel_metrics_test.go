@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+//
+// Modifications Copyright OpenSearch Contributors. See
+// GitHub history for details.
+
+package opensearchtransport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestOtelMetricsRecordRequestBodyNilQuery(t *testing.T) {
+	m := NewOtelMetrics(nil).(*OtelMetrics)
+
+	got := m.RecordRequestBody(context.Background(), "search", nil)
+	if got != nil {
+		t.Fatalf("RecordRequestBody(nil) = %v, want nil", got)
+	}
+}
+
+func TestOtelMetricsRecordRequestBodyCountsBytes(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m := NewOtelMetrics(provider).(*OtelMetrics)
+
+	body := m.RecordRequestBody(context.Background(), "search", strings.NewReader("hello"))
+	if body == nil {
+		t.Fatal("RecordRequestBody(non-nil) = nil, want a reader")
+	}
+	if _, err := io.ReadAll(body); err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if err := body.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	got := collectMetric(t, reader, metricRequestBodySize)
+	if got == nil {
+		t.Fatal("request body size metric was not recorded")
+	}
+	hist, ok := got.Data.(metricdata.Histogram[int64])
+	if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Sum != 5 {
+		t.Errorf("request body size = %+v, want one data point summing to 5 bytes", got.Data)
+	}
+}
+
+// collectMetric runs one collection pass and returns the named metric, or nil
+// if it wasn't recorded.
+func collectMetric(t *testing.T, reader *sdkmetric.ManualReader, name string) *metricdata.Metrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for i, m := range sm.Metrics {
+			if m.Name == name {
+				return &sm.Metrics[i]
+			}
+		}
+	}
+	return nil
+}
+
+func TestOtelMetricsLifecycleRecordsDurationAndRetries(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m := NewOtelMetrics(provider).(*OtelMetrics)
+
+	ctx := m.Start(context.Background(), "search")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://node1:9200/_search", nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext() error = %v", err)
+	}
+	m.AfterRequest(req)
+
+	ctx = context.WithValue(ctx, retryCountContextKey{}, 2)
+	res := &http.Response{StatusCode: 200, ContentLength: 10}
+	m.AfterResponse(ctx, res)
+	m.Close(ctx)
+
+	if got := collectMetric(t, reader, metricOperationDuration); got == nil {
+		t.Error("operation duration metric was not recorded")
+	}
+	if got := collectMetric(t, reader, metricRequestRetries); got == nil {
+		t.Error("retries metric was not recorded")
+	}
+	if got := collectMetric(t, reader, metricResponseBodySize); got == nil {
+		t.Error("response body size metric was not recorded")
+	}
+}
+
+type fakePoolStatser struct {
+	size, dead int
+}
+
+func (f fakePoolStatser) PoolSize() int        { return f.size }
+func (f fakePoolStatser) DeadConnections() int { return f.dead }
+
+func TestOtelMetricsConnectionPoolGauges(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m := NewOtelMetrics(provider).(*OtelMetrics)
+
+	m.SetConnectionPool(fakePoolStatser{size: 3, dead: 1})
+
+	if got := collectMetric(t, reader, metricConnectionPoolSize); got == nil {
+		t.Error("connection pool size gauge was not recorded")
+	}
+	if got := collectMetric(t, reader, metricConnectionsDead); got == nil {
+		t.Error("connections dead gauge was not recorded")
+	}
+}
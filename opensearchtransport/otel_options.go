@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+//
+// Modifications Copyright OpenSearch Contributors. See
+// GitHub history for details.
+
+package opensearchtransport
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelConfig holds the configuration built up by a set of OtelOption and
+// consumed by NewOtelInstrumentationWithOptions.
+type otelConfig struct {
+	provider             trace.TracerProvider
+	propagator           propagation.TextMapPropagator
+	version              string
+	captureSearchBody    bool
+	spanStartOptions     []trace.SpanStartOption
+	spanNameFormatter    func(operation string, req *http.Request) string
+	publicEndpoint       bool
+	publicEndpointFn     func() bool
+	recordedEndpoints    map[string]struct{}
+	maxRecordedBodyBytes int
+}
+
+// OtelOption configures an OpensearchOpenTelemetry instance created by
+// NewOtelInstrumentationWithOptions.
+type OtelOption func(*otelConfig)
+
+// WithTracerProvider sets the trace.TracerProvider used to create the tracer.
+// If not set, the instrumentation falls back to the global otel provider.
+func WithTracerProvider(provider trace.TracerProvider) OtelOption {
+	return func(cfg *otelConfig) {
+		if provider != nil {
+			cfg.provider = provider
+		}
+	}
+}
+
+// WithPropagator sets the propagation.TextMapPropagator used by BeforeRequest
+// to inject TraceContext and Baggage headers into outgoing requests. If not
+// set, the instrumentation falls back to the global otel propagator.
+func WithPropagator(propagator propagation.TextMapPropagator) OtelOption {
+	return func(cfg *otelConfig) {
+		if propagator != nil {
+			cfg.propagator = propagator
+		}
+	}
+}
+
+// WithVersion sets the instrumentation version reported to the tracer provider.
+func WithVersion(version string) OtelOption {
+	return func(cfg *otelConfig) {
+		cfg.version = version
+	}
+}
+
+// WithSpanStartOptions appends trace.SpanStartOption values applied to every
+// span created by Start, in addition to the client-kind and db.* attributes
+// the instrumentation always sets.
+func WithSpanStartOptions(opts ...trace.SpanStartOption) OtelOption {
+	return func(cfg *otelConfig) {
+		cfg.spanStartOptions = append(cfg.spanStartOptions, opts...)
+	}
+}
+
+// WithSpanNameFormatter overrides the span name, computed once the outgoing
+// request is available, from the operation name and the request.
+func WithSpanNameFormatter(f func(operation string, req *http.Request) string) OtelOption {
+	return func(cfg *otelConfig) {
+		cfg.spanNameFormatter = f
+	}
+}
+
+// WithPublicEndpoint configures the instrumentation to always link to, rather
+// than parent from, any remote span context already present in the calling
+// context. Use this when the client crosses a trust boundary and the caller's
+// trace context should not be treated as part of the same trace.
+func WithPublicEndpoint() OtelOption {
+	return func(cfg *otelConfig) {
+		cfg.publicEndpoint = true
+	}
+}
+
+// WithPublicEndpointFn is like WithPublicEndpoint, but the decision is made by
+// calling f for each call instead of applying it unconditionally. f is called
+// from Start, before the outgoing request is built, so it cannot inspect the
+// request; use WithPublicEndpoint for the common always-public case.
+func WithPublicEndpointFn(f func() bool) OtelOption {
+	return func(cfg *otelConfig) {
+		cfg.publicEndpointFn = f
+	}
+}
+
+// WithCaptureSearchBody sets the query capture behavior for search endpoints.
+func WithCaptureSearchBody(captureSearchBody bool) OtelOption {
+	return func(cfg *otelConfig) {
+		cfg.captureSearchBody = captureSearchBody
+	}
+}
+
+// WithMaxRecordedBodyBytes caps the number of request/response body bytes
+// recorded onto a span. Bodies larger than n are truncated, and the span is
+// marked with a db.statement.truncated (request) or
+// db.response.statement.truncated (response) attribute. Defaults to 64 KiB.
+func WithMaxRecordedBodyBytes(n int) OtelOption {
+	return func(cfg *otelConfig) {
+		cfg.maxRecordedBodyBytes = n
+	}
+}
+
+// WithRecordedEndpoints extends the default search-endpoint allow list used by
+// RecordRequestBody with additional endpoint names, e.g. "_sql" or "_ppl".
+func WithRecordedEndpoints(endpoints map[string]struct{}) OtelOption {
+	return func(cfg *otelConfig) {
+		if cfg.recordedEndpoints == nil {
+			cfg.recordedEndpoints = make(map[string]struct{}, len(endpoints))
+		}
+		for endpoint := range endpoints {
+			cfg.recordedEndpoints[endpoint] = struct{}{}
+		}
+	}
+}